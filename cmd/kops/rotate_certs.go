@@ -0,0 +1,116 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// RotateCertsOptions holds the flags for "kops rotate certs".
+type RotateCertsOptions struct {
+	ClusterName string
+	// Keypair, when set, restricts rotation to just this one leaf keypair (e.g. "etcd-client") instead
+	// of every leaf certificate due for renewal. It is still only rotated if due, unless Force is set.
+	Keypair string
+	// Force rotates even keypairs that aren't yet within their RenewBefore window - used to respond
+	// to a suspected key compromise rather than routine rotation.
+	Force bool
+	Yes   bool
+}
+
+// NewCmdRotateCerts forces re-signing of leaf certificates that are within their RenewBefore window
+// (or, with --force, unconditionally), out of band from the cert-rotator controller's own schedule -
+// e.g. right after cluster.spec.certManager.leafCertificateRenewBefore is widened, or in response to
+// a suspected compromise.
+func NewCmdRotateCerts(f *Factory, out io.Writer) *cobra.Command {
+	options := &RotateCertsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "certs",
+		Short: "Rotate the cluster's leaf certificates",
+		Long: "Re-signs leaf certificates that are within their RenewBefore window. Masters and " +
+			"nodes must still be rolled (see `kops rolling-update cluster`) to pick up the newly-signed " +
+			"keyset; this command only updates the keystore.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName()
+			return RunRotateCerts(f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Keypair, "keypair", options.Keypair,
+		"rotate only this keypair, instead of every keypair due for renewal")
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force,
+		"rotate even keypairs that are not yet within their RenewBefore window")
+	cmd.Flags().BoolVar(&options.Yes, "yes", options.Yes, "actually rotate, instead of dry-running")
+
+	return cmd
+}
+
+// RunRotateCerts re-signs due leaf certificates in the cluster's keystore.
+func RunRotateCerts(f *Factory, out io.Writer, options *RotateCertsOptions) error {
+	if options.ClusterName == "" {
+		return fmt.Errorf("--name is required")
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	keyStore, err := clientset.KeyStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	due, err := keyStore.FindKeypairsDueForRenewal(options.Keypair, options.Force)
+	if err != nil {
+		return err
+	}
+
+	if len(due) == 0 {
+		_, err = fmt.Fprintln(out, "no keypairs are due for rotation")
+		return err
+	}
+
+	for _, name := range due {
+		if !options.Yes {
+			if _, err := fmt.Fprintf(out, "%s is due for rotation\n", name); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := keyStore.RotateKeypair(name); err != nil {
+			return fmt.Errorf("error rotating keypair %q: %v", name, err)
+		}
+		if _, err := fmt.Fprintf(out, "rotated %s\n", name); err != nil {
+			return err
+		}
+	}
+
+	if !options.Yes {
+		_, err = fmt.Fprintf(out, "\nMust specify --yes to rotate certificates\n")
+	}
+	return err
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// KubeAPIServerBuilder builds the kube-apiserver static pod manifest on a master. Only the flag
+// assembly relevant to CA-bundle trust is reconstructed here; the rest of the manifest (the many
+// other flags, volume mounts, etc.) is built elsewhere.
+type KubeAPIServerBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.ModelBuilder = &KubeAPIServerBuilder{}
+
+// Build assembles the kube-apiserver flags and writes the manifest.
+func (b *KubeAPIServerBuilder) Build(c *fi.ModelBuilderContext) error {
+	flags := b.kubeAPIServerFlags()
+	return b.buildManifest(c, flags)
+}
+
+// kubeAPIServerFlags merges the trust-bundle flags from KubeAPIServerTrustFlags on top of the rest
+// of the apiserver's flags, so the per-purpose CA split done by pkg/model.PKIModelBuilder is what the
+// running apiserver actually trusts - overwriting whatever single-CA --client-ca-file,
+// --requestheader-client-ca-file, and --kubelet-certificate-authority it would otherwise have used.
+func (b *KubeAPIServerBuilder) kubeAPIServerFlags() map[string]string {
+	flags := b.baseFlags()
+	for k, v := range KubeAPIServerTrustFlags() {
+		flags[k] = v
+	}
+	return flags
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// KubeAPIServerTrustFlags returns the kube-apiserver command-line flags that reference a CA bundle
+// rather than a single CA certificate, so that a rotation-in-progress extra CA or a spliced-in
+// corporate root (cluster.spec.certManager.additionalTrustedCAs) is trusted without the apiserver
+// needing its own copy of that logic.
+func KubeAPIServerTrustFlags() map[string]string {
+	return map[string]string{
+		// General client trust: kubelet, kube-proxy, kube-scheduler, kube-controller-manager, kubecfg.
+		"--client-ca-file": CABundlePath("apiserver-client-ca"),
+		// Trust for the aggregation layer's proxy client certificate.
+		"--requestheader-client-ca-file": CABundlePath("apiserver-aggregator-ca"),
+		// Trust for connections the apiserver makes directly to kubelets.
+		"--kubelet-certificate-authority": CABundlePath("apiserver-to-kubelet-ca"),
+	}
+}
+
+// KubeletTrustFlags returns the kubelet command-line flags that reference a CA bundle, mirroring
+// KubeAPIServerTrustFlags for the kubelet side of the same trust relationship.
+func KubeletTrustFlags() map[string]string {
+	return map[string]string{
+		"--client-ca-file": CABundlePath("kubelet-client-ca"),
+	}
+}
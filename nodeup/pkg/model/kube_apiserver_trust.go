@@ -0,0 +1,61 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// trustBundleForPurpose maps each trust relationship the master components participate in to the
+// *-ca-bundle task name (see pkg/model.PKIModelBuilder.addCABundle) that should be read off disk for
+// it, instead of a single CA certificate. Splitting the CAs by purpose only isolates a key
+// compromise if every consumer actually reads the narrower bundle for its own relationship.
+var trustBundleForPurpose = map[string]string{
+	// kube-apiserver's --client-ca-file: general cluster client trust (kubelet, kube-proxy,
+	// kube-scheduler, kube-controller-manager, kubecfg, ...).
+	"apiserver-client-ca": "ca-bundle",
+	// kube-apiserver's --kubelet-certificate-authority: trust for the kubelet *serving* certificate
+	// the apiserver validates when it dials a kubelet directly for exec/attach/logs/port-forward.
+	// That serving certificate is signed by defaultCA (ca-bundle), not kube-apiserver-to-kubelet-ca -
+	// the latter only signs kubelet-api, the apiserver's own *client* certificate to kubelets.
+	"apiserver-to-kubelet-ca": "ca-bundle",
+	// kube-apiserver's --requestheader-client-ca-file, for the aggregation layer.
+	"apiserver-aggregator-ca": "apiserver-aggregator-ca-bundle",
+	// The bundle any client outside the master (kubelet bootstrap, the generated kubecfg/admin
+	// kubeconfig, the kops CLI) must trust to validate the apiserver's own serving certificate when
+	// dialing its public/load-balancer-facing name. After the master serving certificate was split
+	// per trust domain, that's kube-apiserver-serving-loadbalancer-ca, not defaultCA.
+	"apiserver-serving-ca": "kube-apiserver-serving-loadbalancer-ca-bundle",
+	// kubelet's --client-ca-file, for validating the apiserver's incoming client connections.
+	"kubelet-client-ca": "kube-apiserver-to-kubelet-ca-bundle",
+	// etcd's peer and server trust.
+	"etcd-ca": "etcd-ca-bundle",
+	// etcd's --client-cert-auth trust (used by the apiserver and calico as etcd clients).
+	"etcd-client-ca": "etcd-client-ca-bundle",
+}
+
+// CABundlePath returns the nodeup-local path a master-role component should read for the given trust
+// relationship's CA bundle. Masters mirror every *-ca-bundle task under this directory (see
+// fitasks.MirrorKeystore), so components never need their own copy of the splitting logic above.
+//
+// The apiserver's own --tls-cert-file/SNI serving side (which of the three
+// kube-apiserver-serving-*-ca signed keypairs to present for a given incoming SNI name) is assembled
+// by the manifest builder, not here; it reads the master-serving-* keypairs directly rather than
+// through a bundle purpose, since it's presenting a certificate rather than trusting one.
+func CABundlePath(purpose string) string {
+	bundleName, ok := trustBundleForPurpose[purpose]
+	if !ok {
+		bundleName = "ca-bundle"
+	}
+	return "/etc/kubernetes/pki/" + bundleName + ".crt"
+}
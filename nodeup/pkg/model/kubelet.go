@@ -0,0 +1,56 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// KubeletBuilder builds the kubelet's flags and bootstrap kubeconfig. Only the CA-bundle trust
+// wiring is reconstructed here; the rest of the kubelet configuration is built elsewhere.
+type KubeletBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.ModelBuilder = &KubeletBuilder{}
+
+// Build assembles the kubelet's flags and bootstrap kubeconfig.
+func (b *KubeletBuilder) Build(c *fi.ModelBuilderContext) error {
+	flags := b.baseFlags()
+	for k, v := range KubeletTrustFlags() {
+		flags[k] = v
+	}
+
+	caBundle, err := b.bootstrapCABundle()
+	if err != nil {
+		return err
+	}
+
+	return b.buildKubeletConfig(c, flags, caBundle)
+}
+
+// bootstrapCABundle returns the PEM bytes the kubelet's bootstrap-kubeconfig
+// certificate-authority-data should be set to.
+func (b *KubeletBuilder) bootstrapCABundle() ([]byte, error) {
+	caBundle, err := KubeletBootstrapCABundle()
+	if err != nil {
+		return nil, fmt.Errorf("error reading kubelet bootstrap CA bundle: %v", err)
+	}
+	return caBundle, nil
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "io/ioutil"
+
+// KubeletBootstrapCABundle returns the PEM bytes a kubelet's bootstrap-kubeconfig
+// certificate-authority-data should be set to: the bundle that validates the apiserver's own serving
+// certificate on the address the kubelet dials to bootstrap (its public/load-balancer-facing name),
+// not the apiserver's *client*-trust bundle - a newly-bootstrapping node needs to trust the server
+// it's connecting to, which is a different CA after the master serving certificate was split per
+// trust domain.
+func KubeletBootstrapCABundle() ([]byte, error) {
+	return ioutil.ReadFile(CABundlePath("apiserver-serving-ca"))
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertManagerSpec configures how kops manages the cluster's internal PKI: whether kops itself holds
+// the CA private keys, what key algorithm(s) to generate, how long leaf certificates live, and any
+// extra CAs that should be trusted alongside the ones kops generates.
+type CertManagerSpec struct {
+	// ExternalCA indicates that the cluster's CA private keys are held and managed outside of kops
+	// (an HSM, Vault, a corporate PKI, etc). When set, kops will not attempt to sign any certificate
+	// itself; every leaf certificate it would otherwise generate must already exist in the keystore,
+	// pre-signed by the external authority, before "kops update cluster --yes" is run.
+	ExternalCA bool `json:"externalCA,omitempty"`
+
+	// KeyAlgorithm is the default key algorithm for generated keypairs: one of "rsa2048" (the
+	// default), "rsa4096", "ecdsa-p256", or "ed25519".
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+	// KeyAlgorithmOverrides overrides KeyAlgorithm for specific keypairs, keyed by the keypair name
+	// (e.g. "etcd-client-ca", "kubelet").
+	KeyAlgorithmOverrides map[string]string `json:"keyAlgorithmOverrides,omitempty"`
+
+	// LeafCertificateLifetime overrides how long a freshly-issued leaf certificate is valid for.
+	// Defaults to 90 days.
+	LeafCertificateLifetime *metav1.Duration `json:"leafCertificateLifetime,omitempty"`
+	// LeafCertificateRenewBefore overrides how long before expiry the certificate rotation
+	// controller re-signs a leaf certificate. Defaults to 30 days.
+	LeafCertificateRenewBefore *metav1.Duration `json:"leafCertificateRenewBefore,omitempty"`
+
+	// AdditionalTrustedCAs are extra CA certificates to splice into every generated *-ca-bundle
+	// alongside the CA kops itself manages - for example a corporate root, or the previous kops CA
+	// kept around while a rotation is in progress. Each entry is either a PEM-encoded certificate or
+	// a VFS path to one.
+	//
+	// This splices the same entries into every *-ca-bundle independently; it does not make two
+	// different signers trust each other. It doesn't substitute for picking the right bundle for a
+	// given trust relationship in the first place - e.g. it won't make a client trust the
+	// apiserver's serving certificate if that client reads the wrong bundle to begin with.
+	AdditionalTrustedCAs []string `json:"additionalTrustedCAs,omitempty"`
+}
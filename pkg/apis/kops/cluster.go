@@ -0,0 +1,74 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+// Cluster represents a kops-managed Kubernetes cluster.
+type Cluster struct {
+	ObjectMeta metav1ObjectMeta `json:"metadata,omitempty"`
+	Spec       ClusterSpec      `json:"spec,omitempty"`
+}
+
+// metav1ObjectMeta is the minimal subset of metav1.ObjectMeta the model package relies on: just
+// the name field.
+type metav1ObjectMeta struct {
+	Name string `json:"name,omitempty"`
+}
+
+// ClusterSpec is the cluster configuration. Only the fields PKIModelBuilder and its neighbours
+// actually read are reconstructed here.
+type ClusterSpec struct {
+	// MasterPublicName is the external DNS name for the masters, e.g. api.cluster.example.com.
+	MasterPublicName string `json:"masterPublicName,omitempty"`
+	// MasterInternalName is the internal DNS name for the masters, e.g. api.internal.cluster.example.com.
+	MasterInternalName string `json:"masterInternalName,omitempty"`
+	// AdditionalSANs are extra subject-alternative-names for the master serving certificate's
+	// load-balancer-facing identity, e.g. a custom public hostname.
+	AdditionalSANs []string `json:"additionalSANs,omitempty"`
+	// ClusterDNSDomain is the DNS domain used for in-cluster service discovery, e.g. "cluster.local".
+	ClusterDNSDomain string `json:"clusterDNSDomain,omitempty"`
+
+	// SecretStore is the VFS path where cluster secrets are kept.
+	SecretStore string `json:"secretStore,omitempty"`
+	// KeyStore is the VFS path where the PKI keypairs are kept.
+	KeyStore string `json:"keyStore,omitempty"`
+
+	Networking     *NetworkingSpec     `json:"networking,omitempty"`
+	Authentication *AuthenticationSpec `json:"authentication,omitempty"`
+
+	// CertManager configures how kops manages the cluster's internal PKI.
+	CertManager *CertManagerSpec `json:"certManager,omitempty"`
+}
+
+// NetworkingSpec selects and configures the cluster's CNI provider.
+type NetworkingSpec struct {
+	Calico     *CalicoNetworkingSpec     `json:"calico,omitempty"`
+	Kuberouter *KuberouterNetworkingSpec `json:"kuberouter,omitempty"`
+}
+
+// CalicoNetworkingSpec configures the Calico CNI provider.
+type CalicoNetworkingSpec struct{}
+
+// KuberouterNetworkingSpec configures the kube-router CNI provider.
+type KuberouterNetworkingSpec struct{}
+
+// AuthenticationSpec selects and configures an additional authentication webhook.
+type AuthenticationSpec struct {
+	Heptio *HeptioAuthenticationSpec `json:"heptio,omitempty"`
+}
+
+// HeptioAuthenticationSpec configures the heptio-authenticator-aws webhook.
+type HeptioAuthenticationSpec struct{}
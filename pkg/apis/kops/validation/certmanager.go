@@ -0,0 +1,65 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// validKeyAlgorithms are the key algorithms fitasks.Keypair knows how to generate; kept in sync with
+// util/pkg/pki's supported set.
+var validKeyAlgorithms = map[string]bool{
+	"":           true,
+	"rsa2048":    true,
+	"rsa4096":    true,
+	"ecdsa-p256": true,
+	"ed25519":    true,
+}
+
+// ValidateCertManagerSpec is run as part of "kops update cluster"/"kops edit cluster" validation, so
+// a typo'd algorithm name or a leafCertificateRenewBefore that is not shorter than
+// leafCertificateLifetime is caught before apply rather than surfacing as a cryptic failure deep
+// inside a fitasks.Keypair.Run(). ExternalCA is not checked here; the presence of the pre-signed
+// certificates it requires is checked by pkg/model.PKIModelBuilder.Build.
+func ValidateCertManagerSpec(spec *kops.CertManagerSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec == nil {
+		return allErrs
+	}
+
+	if !validKeyAlgorithms[spec.KeyAlgorithm] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("keyAlgorithm"), spec.KeyAlgorithm,
+			[]string{"rsa2048", "rsa4096", "ecdsa-p256", "ed25519"}))
+	}
+	for name, algorithm := range spec.KeyAlgorithmOverrides {
+		if !validKeyAlgorithms[algorithm] {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("keyAlgorithmOverrides").Key(name), algorithm,
+				[]string{"rsa2048", "rsa4096", "ecdsa-p256", "ed25519"}))
+		}
+	}
+
+	if spec.LeafCertificateLifetime != nil && spec.LeafCertificateRenewBefore != nil {
+		if spec.LeafCertificateRenewBefore.Duration >= spec.LeafCertificateLifetime.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("leafCertificateRenewBefore"),
+				spec.LeafCertificateRenewBefore.Duration, "must be less than leafCertificateLifetime"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestValidateCertManagerSpecNil(t *testing.T) {
+	if errs := ValidateCertManagerSpec(nil, field.NewPath("certManager")); len(errs) != 0 {
+		t.Errorf("ValidateCertManagerSpec(nil) = %v, want no errors", errs)
+	}
+}
+
+func TestValidateCertManagerSpecKeyAlgorithm(t *testing.T) {
+	cases := []struct {
+		name      string
+		algorithm string
+		wantErr   bool
+	}{
+		{"empty defaults to rsa2048", "", false},
+		{"rsa2048", "rsa2048", false},
+		{"ecdsa-p256", "ecdsa-p256", false},
+		{"ed25519", "ed25519", false},
+		{"unknown algorithm", "rsa1024", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			spec := &kops.CertManagerSpec{KeyAlgorithm: c.algorithm}
+			errs := ValidateCertManagerSpec(spec, field.NewPath("certManager"))
+			if (len(errs) != 0) != c.wantErr {
+				t.Errorf("ValidateCertManagerSpec(%q) errs=%v, wantErr=%v", c.algorithm, errs, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCertManagerSpecKeyAlgorithmOverrides(t *testing.T) {
+	spec := &kops.CertManagerSpec{
+		KeyAlgorithmOverrides: map[string]string{
+			"etcd-client-ca": "ecdsa-p256",
+			"kubelet":        "bogus",
+		},
+	}
+	errs := ValidateCertManagerSpec(spec, field.NewPath("certManager"))
+	if len(errs) != 1 {
+		t.Fatalf("ValidateCertManagerSpec() = %v, want exactly 1 error for the bogus override", errs)
+	}
+}
+
+func TestValidateCertManagerSpecLeafLifetimes(t *testing.T) {
+	cases := []struct {
+		name        string
+		lifetime    *metav1.Duration
+		renewBefore *metav1.Duration
+		wantErr     bool
+	}{
+		{"both unset", nil, nil, false},
+		{"renewBefore less than lifetime", durationPtr(90 * 24), durationPtr(30 * 24), false},
+		{"renewBefore equal to lifetime", durationPtr(30 * 24), durationPtr(30 * 24), true},
+		{"renewBefore greater than lifetime", durationPtr(30 * 24), durationPtr(90 * 24), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			spec := &kops.CertManagerSpec{LeafCertificateLifetime: c.lifetime, LeafCertificateRenewBefore: c.renewBefore}
+			errs := ValidateCertManagerSpec(spec, field.NewPath("certManager"))
+			if (len(errs) != 0) != c.wantErr {
+				t.Errorf("ValidateCertManagerSpec() errs=%v, wantErr=%v", errs, c.wantErr)
+			}
+		})
+	}
+}
+
+func durationPtr(hours int) *metav1.Duration {
+	return &metav1.Duration{Duration: time.Duration(hours) * time.Hour}
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// ValidateCluster is run as part of "kops update cluster"/"kops edit cluster". Only the
+// cluster.spec.certManager validation is reconstructed here; the rest of cluster validation lives
+// elsewhere.
+func ValidateCluster(cluster *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	allErrs = append(allErrs, ValidateCertManagerSpec(cluster.Spec.CertManager, fldPath.Child("certManager"))...)
+
+	return allErrs
+}
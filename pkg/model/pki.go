@@ -18,7 +18,11 @@ package model
 
 import (
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/kops/pkg/tokens"
 	"k8s.io/kops/upup/pkg/fi"
@@ -26,10 +30,61 @@ import (
 	"k8s.io/kops/util/pkg/vfs"
 )
 
+// Leaf certificates default to a short lifetime so that a compromised key is only useful for a
+// bounded window; the rotation controller re-signs them well before they expire. CAs are left at
+// fitasks.Keypair's own (multi-year) default, since rotating a signer is a much heavier operation.
+const (
+	defaultLeafCertificateLifetime    = 90 * 24 * time.Hour
+	defaultLeafCertificateRenewBefore = 30 * 24 * time.Hour
+)
+
+// externalCALeafCertificates are the leaf keypairs kops normally signs itself, required
+// unconditionally. When cluster.spec.certManager.externalCA is set, kops does not hold the CA
+// private key, so these must already exist in the KeyStore/SecretStore VFS paths, pre-signed by
+// whatever external authority (HSM, Vault, etc.) holds the CA.
+//
+// "etcd" and "etcd-client" are deliberately not listed here: those leaves, like their etcd-ca/
+// etcd-client-ca signers, are only generated when UseEtcdTLS() is set (see Build), so
+// externalCARequiredCertificates only requires them in that same case.
+var externalCALeafCertificates = []string{
+	"kubelet",
+	"kubelet-api",
+	"kube-scheduler",
+	"kube-proxy",
+	"kube-controller-manager",
+	"kubecfg",
+	"kops",
+	"apiserver-proxy-client",
+	"apiserver-aggregator",
+	"master-serving-loadbalancer",
+	"master-serving-localhost",
+	"master-serving-service-network",
+}
+
+// externalCASigners are the self-signed CA keypairs kops normally generates itself, required
+// unconditionally. fitasks.Keypair only refuses to act when a *leaf's* signer has no private key; a
+// CA keypair has no Signer at all, so without checking these too kops would happily mint a brand-new
+// self-signed CA in external-CA mode - exactly what that mode must forbid.
+var externalCASigners = []string{
+	fi.CertificateId_CA,
+	"kube-apiserver-to-kubelet-ca",
+	"kube-apiserver-serving-loadbalancer-ca",
+	"kube-apiserver-serving-localhost-ca",
+	"kube-apiserver-serving-service-network-ca",
+	"apiserver-aggregator-ca",
+}
+
 // PKIModelBuilder configures PKI keypairs, as well as tokens
 type PKIModelBuilder struct {
 	*KopsModelContext
 	Lifecycle *fi.Lifecycle
+
+	// resolveAdditionalOnce guards resolvedAdditional/resolveAdditionalErr, so a cluster with several
+	// *-ca-bundle tasks resolves cluster.spec.certManager.additionalTrustedCAs' VFS paths a single
+	// time during Build rather than once per bundle.
+	resolveAdditionalOnce sync.Once
+	resolvedAdditional    []string
+	resolveAdditionalErr  error
 }
 
 var _ fi.ModelBuilder = &PKIModelBuilder{}
@@ -37,6 +92,21 @@ var _ fi.ModelBuilder = &PKIModelBuilder{}
 // Build is responsible for generating the various pki assets.
 func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 
+	// kubeadm-style "external CA" mode: the CA private key is not in our keystore, so we must not
+	// try to sign anything off it ourselves. Fail fast here, rather than partway through apply, if
+	// the leaf certificates we'd otherwise generate haven't been pre-signed and published already.
+	if b.Cluster.Spec.CertManager != nil && b.Cluster.Spec.CertManager.ExternalCA {
+		if err := b.validateExternalCAMaterial(); err != nil {
+			return err
+		}
+	}
+
+	// Resolve additionalTrustedCAs' VFS paths once, up front, so a bad path fails the whole build
+	// immediately rather than partway through adding *-ca-bundle tasks.
+	if _, err := b.resolvedAdditionalTrustedCAs(); err != nil {
+		return err
+	}
+
 	// We specify the KeysetFormatV1Alpha2 format, to upgrade from the legacy representation (separate files)
 	// to the newer keyset.yaml representation.
 	format := string(fi.KeysetFormatV1Alpha2)
@@ -48,19 +118,81 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 		Subject:   "cn=kubernetes",
 		Type:      "ca",
 		Format:    format,
+		Algorithm: b.keyAlgorithm(fi.CertificateId_CA),
 	}
 	c.AddTask(defaultCA)
 
+	// ca-bundle composes defaultCA's certificate with any additionalTrustedCAs into a single PEM.
+	// The kube-apiserver's --client-ca-file and --kubelet-certificate-authority reference this bundle
+	// rather than defaultCA's bare certificate, so that a spliced-in corporate root or the previous
+	// kops CA (kept around during a rotation) are trusted alongside it. --requestheader-client-ca-file
+	// reads apiserver-aggregator-ca-bundle, and kubelet bootstrap and the generated kubecfg/admin
+	// kubeconfig read kube-apiserver-serving-loadbalancer-ca-bundle; those bundles carry the same
+	// additionalTrustedCAs (see addCABundle).
+	b.addCABundle(c, "ca-bundle", defaultCA)
+
+	// kubeAPIServerToKubeletCA signs the client certificate the kube-apiserver uses when it talks
+	// directly to kubelets (exec/attach/logs/port-forward). Splitting this out of defaultCA means a
+	// leak of the general cluster CA doesn't also hand out kubelet-impersonation rights, and vice versa.
+	kubeAPIServerToKubeletCA := &fitasks.Keypair{
+		Name:      fi.String("kube-apiserver-to-kubelet-ca"),
+		Lifecycle: b.Lifecycle,
+		Subject:   "cn=kube-apiserver-to-kubelet-ca",
+		Type:      "ca",
+		Format:    format,
+		Algorithm: b.keyAlgorithm("kube-apiserver-to-kubelet-ca"),
+	}
+	c.AddTask(kubeAPIServerToKubeletCA)
+	b.addCABundle(c, "kube-apiserver-to-kubelet-ca-bundle", kubeAPIServerToKubeletCA)
+
+	// The master serving certificate is split into three, one per signer, so that trust in one
+	// SAN group (e.g. the public load balancer name) doesn't imply trust for another (e.g. the
+	// in-cluster service network name).
+	kubeAPIServerServingLBCA := &fitasks.Keypair{
+		Name:      fi.String("kube-apiserver-serving-loadbalancer-ca"),
+		Lifecycle: b.Lifecycle,
+		Subject:   "cn=kube-apiserver-serving-loadbalancer-ca",
+		Type:      "ca",
+		Format:    format,
+		Algorithm: b.keyAlgorithm("kube-apiserver-serving-loadbalancer-ca"),
+	}
+	c.AddTask(kubeAPIServerServingLBCA)
+	b.addCABundle(c, "kube-apiserver-serving-loadbalancer-ca-bundle", kubeAPIServerServingLBCA)
+
+	kubeAPIServerServingLocalhostCA := &fitasks.Keypair{
+		Name:      fi.String("kube-apiserver-serving-localhost-ca"),
+		Lifecycle: b.Lifecycle,
+		Subject:   "cn=kube-apiserver-serving-localhost-ca",
+		Type:      "ca",
+		Format:    format,
+		Algorithm: b.keyAlgorithm("kube-apiserver-serving-localhost-ca"),
+	}
+	c.AddTask(kubeAPIServerServingLocalhostCA)
+	b.addCABundle(c, "kube-apiserver-serving-localhost-ca-bundle", kubeAPIServerServingLocalhostCA)
+
+	kubeAPIServerServingServiceNetworkCA := &fitasks.Keypair{
+		Name:      fi.String("kube-apiserver-serving-service-network-ca"),
+		Lifecycle: b.Lifecycle,
+		Subject:   "cn=kube-apiserver-serving-service-network-ca",
+		Type:      "ca",
+		Format:    format,
+		Algorithm: b.keyAlgorithm("kube-apiserver-serving-service-network-ca"),
+	}
+	c.AddTask(kubeAPIServerServingServiceNetworkCA)
+	b.addCABundle(c, "kube-apiserver-serving-service-network-ca-bundle", kubeAPIServerServingServiceNetworkCA)
+
 	{
 
 		t := &fitasks.Keypair{
-			Name:      fi.String("kubelet"),
-			Lifecycle: b.Lifecycle,
-
-			Subject: "o=" + user.NodesGroup + ",cn=kubelet",
-			Type:    "client",
-			Signer:  defaultCA,
-			Format:  format,
+			Name:        fi.String("kubelet"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "o=" + user.NodesGroup + ",cn=kubelet",
+			Type:        "client",
+			Signer:      defaultCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("kubelet"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(t)
 	}
@@ -68,47 +200,62 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 		// Generate a kubelet client certificate for api to speak securely to kubelets. This change was first
 		// introduced in https://github.com/kubernetes/kops/pull/2831 where server.cert/key were used. With kubernetes >= 1.7
 		// the certificate usage is being checked (obviously the above was server not client certificate) and so now fails
+		//
+		// Signed by kubeAPIServerToKubeletCA rather than defaultCA: this is the one client certificate
+		// that grants direct access to every kubelet, so it gets its own, narrowly-trusted signer.
 		c.AddTask(&fitasks.Keypair{
-			Name:      fi.String("kubelet-api"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "cn=kubelet-api",
-			Type:      "client",
-			Signer:    defaultCA,
-			Format:    format,
+			Name:        fi.String("kubelet-api"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "cn=kubelet-api",
+			Type:        "client",
+			Signer:      kubeAPIServerToKubeletCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("kubelet-api"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		})
 	}
 	{
 		t := &fitasks.Keypair{
-			Name:      fi.String("kube-scheduler"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "cn=" + user.KubeScheduler,
-			Type:      "client",
-			Signer:    defaultCA,
-			Format:    format,
+			Name:        fi.String("kube-scheduler"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "cn=" + user.KubeScheduler,
+			Type:        "client",
+			Signer:      defaultCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("kube-scheduler"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(t)
 	}
 
 	{
 		t := &fitasks.Keypair{
-			Name:      fi.String("kube-proxy"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "cn=" + user.KubeProxy,
-			Type:      "client",
-			Signer:    defaultCA,
-			Format:    format,
+			Name:        fi.String("kube-proxy"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "cn=" + user.KubeProxy,
+			Type:        "client",
+			Signer:      defaultCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("kube-proxy"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(t)
 	}
 
 	{
 		t := &fitasks.Keypair{
-			Name:      fi.String("kube-controller-manager"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "cn=" + user.KubeControllerManager,
-			Type:      "client",
-			Signer:    defaultCA,
-			Format:    format,
+			Name:        fi.String("kube-controller-manager"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "cn=" + user.KubeControllerManager,
+			Type:        "client",
+			Signer:      defaultCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("kube-controller-manager"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(t)
 	}
@@ -119,6 +266,32 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 	// For clients assuming we are using etcdv3 is can switch on user authentication and map the common names for auth.
 	if b.UseEtcdTLS() {
 		alternativeNames := []string{fmt.Sprintf("*.internal.%s", b.ClusterName()), "localhost", "127.0.0.1"}
+
+		// etcd gets its own signer pair (peer/server CA and client CA) instead of hanging off
+		// defaultCA: etcd trust is cluster-internal and shouldn't be widened by every other
+		// component that also trusts the general cluster CA.
+		etcdCA := &fitasks.Keypair{
+			Name:      fi.String("etcd-ca"),
+			Lifecycle: b.Lifecycle,
+			Subject:   "cn=etcd-ca",
+			Type:      "ca",
+			Format:    format,
+			Algorithm: b.keyAlgorithm("etcd-ca"),
+		}
+		c.AddTask(etcdCA)
+		b.addCABundle(c, "etcd-ca-bundle", etcdCA)
+
+		etcdClientCA := &fitasks.Keypair{
+			Name:      fi.String("etcd-client-ca"),
+			Lifecycle: b.Lifecycle,
+			Subject:   "cn=etcd-client-ca",
+			Type:      "ca",
+			Format:    format,
+			Algorithm: b.keyAlgorithm("etcd-client-ca"),
+		}
+		c.AddTask(etcdClientCA)
+		b.addCABundle(c, "etcd-client-ca-bundle", etcdClientCA)
+
 		// @question should wildcard's be here instead of generating per node. If we ever provide the
 		// ability to resize the master, this will become a blocker
 		c.AddTask(&fitasks.Keypair{
@@ -127,62 +300,80 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 			Name:           fi.String("etcd"),
 			Subject:        "cn=etcd",
 			Type:           "clientServer",
-			Signer:         defaultCA,
+			Signer:         etcdCA,
 			Format:         format,
+			Algorithm:      b.keyAlgorithm("etcd"),
+			Lifetime:       b.leafLifetime(),
+			RenewBefore:    b.leafRenewBefore(),
 		})
 		c.AddTask(&fitasks.Keypair{
-			Name:      fi.String("etcd-client"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "cn=etcd-client",
-			Type:      "client",
-			Signer:    defaultCA,
-			Format:    format,
+			Name:        fi.String("etcd-client"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "cn=etcd-client",
+			Type:        "client",
+			Signer:      etcdClientCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("etcd-client"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		})
 
 		// @check if calico is enabled as the CNI provider
 		if b.KopsModelContext.Cluster.Spec.Networking.Calico != nil {
 			c.AddTask(&fitasks.Keypair{
-				Name:      fi.String("calico-client"),
-				Lifecycle: b.Lifecycle,
-				Subject:   "cn=calico-client",
-				Type:      "client",
-				Signer:    defaultCA,
-				Format:    format,
+				Name:        fi.String("calico-client"),
+				Lifecycle:   b.Lifecycle,
+				Subject:     "cn=calico-client",
+				Type:        "client",
+				Signer:      etcdClientCA,
+				Format:      format,
+				Algorithm:   b.keyAlgorithm("calico-client"),
+				Lifetime:    b.leafLifetime(),
+				RenewBefore: b.leafRenewBefore(),
 			})
 		}
 	}
 
 	if b.KopsModelContext.Cluster.Spec.Networking.Kuberouter != nil {
 		t := &fitasks.Keypair{
-			Name:    fi.String("kube-router"),
-			Subject: "cn=" + "system:kube-router",
-			Type:    "client",
-			Signer:  defaultCA,
-			Format:  format,
+			Name:        fi.String("kube-router"),
+			Subject:     "cn=" + "system:kube-router",
+			Type:        "client",
+			Signer:      defaultCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("kube-router"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(t)
 	}
 
 	{
 		t := &fitasks.Keypair{
-			Name:      fi.String("kubecfg"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "o=" + user.SystemPrivilegedGroup + ",cn=kubecfg",
-			Type:      "client",
-			Signer:    defaultCA,
-			Format:    format,
+			Name:        fi.String("kubecfg"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "o=" + user.SystemPrivilegedGroup + ",cn=kubecfg",
+			Type:        "client",
+			Signer:      defaultCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("kubecfg"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(t)
 	}
 
 	{
 		t := &fitasks.Keypair{
-			Name:      fi.String("apiserver-proxy-client"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "cn=apiserver-proxy-client",
-			Type:      "client",
-			Signer:    defaultCA,
-			Format:    format,
+			Name:        fi.String("apiserver-proxy-client"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "cn=apiserver-proxy-client",
+			Type:        "client",
+			Signer:      defaultCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("apiserver-proxy-client"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(t)
 	}
@@ -194,17 +385,22 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 			Subject:   "cn=apiserver-aggregator-ca",
 			Type:      "ca",
 			Format:    format,
+			Algorithm: b.keyAlgorithm("apiserver-aggregator-ca"),
 		}
 		c.AddTask(aggregatorCA)
+		b.addCABundle(c, "apiserver-aggregator-ca-bundle", aggregatorCA)
 
 		aggregator := &fitasks.Keypair{
 			Name:      fi.String("apiserver-aggregator"),
 			Lifecycle: b.Lifecycle,
 			// Must match RequestheaderAllowedNames
-			Subject: "cn=aggregator",
-			Type:    "client",
-			Signer:  aggregatorCA,
-			Format:  format,
+			Subject:     "cn=aggregator",
+			Type:        "client",
+			Signer:      aggregatorCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("apiserver-aggregator"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(aggregator)
 	}
@@ -212,52 +408,92 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 	{
 		// Used by e.g. protokube
 		t := &fitasks.Keypair{
-			Name:      fi.String("kops"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "o=" + user.SystemPrivilegedGroup + ",cn=kops",
-			Type:      "client",
-			Signer:    defaultCA,
-			Format:    format,
+			Name:        fi.String("kops"),
+			Lifecycle:   b.Lifecycle,
+			Subject:     "o=" + user.SystemPrivilegedGroup + ",cn=kops",
+			Type:        "client",
+			Signer:      defaultCA,
+			Format:      format,
+			Algorithm:   b.keyAlgorithm("kops"),
+			Lifetime:    b.leafLifetime(),
+			RenewBefore: b.leafRenewBefore(),
 		}
 		c.AddTask(t)
 	}
 
 	{
-		// A few names used from inside the cluster, which all resolve the same based on our default suffixes
-		alternateNames := []string{
-			"kubernetes",
-			"kubernetes.default",
-			"kubernetes.default.svc",
-			"kubernetes.default.svc." + b.Cluster.Spec.ClusterDNSDomain,
+		// The master serving certificate used to be a single cert with every SAN the apiserver
+		// could ever be reached on piled onto it. It's now split per trust domain, each signed by
+		// its own CA, so that a client that only needs to trust (say) the in-cluster service
+		// network name never has to trust the public load balancer name as well.
+
+		// (b) the public/load-balancer-facing name(s)
+		{
+			alternateNames := []string{b.Cluster.Spec.MasterPublicName}
+			alternateNames = append(alternateNames, b.Cluster.Spec.AdditionalSANs...)
+
+			c.AddTask(&fitasks.Keypair{
+				Name:           fi.String("master-serving-loadbalancer"),
+				Lifecycle:      b.Lifecycle,
+				Subject:        "cn=kubernetes-master",
+				Type:           "server",
+				AlternateNames: alternateNames,
+				Signer:         kubeAPIServerServingLBCA,
+				Format:         format,
+				Algorithm:      b.keyAlgorithm("master-serving-loadbalancer"),
+				Lifetime:       b.leafLifetime(),
+				RenewBefore:    b.leafRenewBefore(),
+			})
 		}
 
-		// Names specified in the cluster spec
-		alternateNames = append(alternateNames, b.Cluster.Spec.MasterPublicName)
-		alternateNames = append(alternateNames, b.Cluster.Spec.MasterInternalName)
-		alternateNames = append(alternateNames, b.Cluster.Spec.AdditionalSANs...)
+		// (c) localhost, so that components on the master can talk to the apiserver over the loopback
+		{
+			alternateNames := []string{"localhost", "127.0.0.1"}
+
+			c.AddTask(&fitasks.Keypair{
+				Name:           fi.String("master-serving-localhost"),
+				Lifecycle:      b.Lifecycle,
+				Subject:        "cn=kubernetes-master",
+				Type:           "server",
+				AlternateNames: alternateNames,
+				Signer:         kubeAPIServerServingLocalhostCA,
+				Format:         format,
+				Algorithm:      b.keyAlgorithm("master-serving-localhost"),
+				Lifetime:       b.leafLifetime(),
+				RenewBefore:    b.leafRenewBefore(),
+			})
+		}
 
-		// Referencing it by internal IP should work also
+		// (d) the in-cluster service-network names, resolved the same based on our default suffixes
 		{
+			alternateNames := []string{
+				"kubernetes",
+				"kubernetes.default",
+				"kubernetes.default.svc",
+				"kubernetes.default.svc." + b.Cluster.Spec.ClusterDNSDomain,
+				b.Cluster.Spec.MasterInternalName,
+			}
+
+			// Referencing it by internal IP should work also
 			ip, err := b.WellKnownServiceIP(1)
 			if err != nil {
 				return err
 			}
 			alternateNames = append(alternateNames, ip.String())
-		}
-
-		// We also want to be able to reference it locally via https://127.0.0.1
-		alternateNames = append(alternateNames, "127.0.0.1")
 
-		t := &fitasks.Keypair{
-			Name:           fi.String("master"),
-			Lifecycle:      b.Lifecycle,
-			Subject:        "cn=kubernetes-master",
-			Type:           "server",
-			AlternateNames: alternateNames,
-			Signer:         defaultCA,
-			Format:         format,
+			c.AddTask(&fitasks.Keypair{
+				Name:           fi.String("master-serving-service-network"),
+				Lifecycle:      b.Lifecycle,
+				Subject:        "cn=kubernetes-master",
+				Type:           "server",
+				AlternateNames: alternateNames,
+				Signer:         kubeAPIServerServingServiceNetworkCA,
+				Format:         format,
+				Algorithm:      b.keyAlgorithm("master-serving-service-network"),
+				Lifetime:       b.leafLifetime(),
+				RenewBefore:    b.leafRenewBefore(),
+			})
 		}
-		c.AddTask(t)
 	}
 
 	if b.Cluster.Spec.Authentication != nil {
@@ -274,6 +510,9 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 				AlternateNames: alternateNames,
 				Signer:         defaultCA,
 				Format:         format,
+				Algorithm:      b.keyAlgorithm("heptio-authenticator-aws"),
+				Lifetime:       b.leafLifetime(),
+				RenewBefore:    b.leafRenewBefore(),
 			}
 			c.AddTask(t)
 		}
@@ -314,3 +553,122 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 
 	return nil
 }
+
+// validateExternalCAMaterial checks that every certificate kops would otherwise generate or sign
+// itself - CAs as well as leaves - already exists in the keystore, for clusters where the CA is
+// externally managed. It returns an actionable error naming exactly what's missing, rather than
+// letting "kops update cluster" fail deep inside a fitasks.Keypair.Run(), or - for a CA keypair,
+// which has no signer to refuse on its own - silently generating a brand-new CA and private key.
+func (b *PKIModelBuilder) validateExternalCAMaterial() error {
+	var missing []string
+	for _, name := range b.externalCARequiredCertificates() {
+		cert, err := b.KeyStore().FindCert(name)
+		if err != nil {
+			return fmt.Errorf("error checking keystore for externally-signed certificate %q: %v", name, err)
+		}
+		if cert == nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("cluster.spec.certManager.externalCA is set, so kops will not generate or sign certificates "+
+			"itself; the following pre-signed certificates are missing from the keystore: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// externalCARequiredCertificates returns every certificate name - CA and leaf alike - that
+// validateExternalCAMaterial requires to already exist, mirroring exactly the set of fitasks.Keypair
+// tasks Build would otherwise add for this cluster's configuration.
+func (b *PKIModelBuilder) externalCARequiredCertificates() []string {
+	names := append([]string{}, externalCALeafCertificates...)
+	names = append(names, externalCASigners...)
+
+	if b.UseEtcdTLS() {
+		names = append(names, "etcd-ca", "etcd-client-ca", "etcd", "etcd-client")
+		if b.KopsModelContext.Cluster.Spec.Networking.Calico != nil {
+			names = append(names, "calico-client")
+		}
+	}
+	if b.KopsModelContext.Cluster.Spec.Networking.Kuberouter != nil {
+		names = append(names, "kube-router")
+	}
+	if b.Cluster.Spec.Authentication != nil && b.KopsModelContext.Cluster.Spec.Authentication.Heptio != nil {
+		names = append(names, "heptio-authenticator-aws")
+	}
+	return names
+}
+
+// keyAlgorithm resolves the key algorithm to use for the named keypair: a per-component override in
+// cluster.spec.certManager.keyAlgorithmOverrides takes precedence over the cluster-wide
+// cluster.spec.certManager.keyAlgorithm default. An empty return value leaves fitasks.Keypair to fall
+// back to its own default (RSA), so existing clusters are unaffected unless the field is set.
+func (b *PKIModelBuilder) keyAlgorithm(name string) string {
+	certManager := b.Cluster.Spec.CertManager
+	if certManager == nil {
+		return ""
+	}
+	if algorithm, ok := certManager.KeyAlgorithmOverrides[name]; ok {
+		return algorithm
+	}
+	return certManager.KeyAlgorithm
+}
+
+// leafLifetime returns the Lifetime to embed on a leaf fitasks.Keypair: how long a freshly-signed
+// leaf certificate should be valid for.
+func (b *PKIModelBuilder) leafLifetime() *metav1.Duration {
+	if certManager := b.Cluster.Spec.CertManager; certManager != nil && certManager.LeafCertificateLifetime != nil {
+		return certManager.LeafCertificateLifetime
+	}
+	return &metav1.Duration{Duration: defaultLeafCertificateLifetime}
+}
+
+// leafRenewBefore returns the RenewBefore to embed on a leaf fitasks.Keypair: how long before expiry
+// the rotation controller should re-sign it (see the equivalent "kops rotate certs" CLI verb for
+// forcing this out of band).
+func (b *PKIModelBuilder) leafRenewBefore() *metav1.Duration {
+	if certManager := b.Cluster.Spec.CertManager; certManager != nil && certManager.LeafCertificateRenewBefore != nil {
+		return certManager.LeafCertificateRenewBefore
+	}
+	return &metav1.Duration{Duration: defaultLeafCertificateRenewBefore}
+}
+
+// addCABundle adds the *-ca-bundle task for signer: every signing CA introduced by the per-purpose
+// split gets its own bundle task, mirrored via MirrorKeystore alongside the keypairs themselves, so
+// that each trust relationship (kubelet bootstrap, etcd peer/client trust, aggregator trust, etc.)
+// has a single well-known bundle name consumers can reference instead of the bare CA certificate.
+// The resolved additionalTrustedCAs are spliced into every bundle alongside signer's own certificate,
+// same as the top-level ca-bundle: a spliced-in corporate root or a previous kops CA should be
+// trusted through every trust relationship that was split off defaultCA, not just the general client
+// one - notably apiserver-aggregator-ca-bundle, which backs --requestheader-client-ca-file. Build
+// resolves additionalTrustedCAs once, up front, and fails before any task is added if that errors -
+// so by the time addCABundle runs, the cached result is known good.
+func (b *PKIModelBuilder) addCABundle(c *fi.ModelBuilderContext, name string, signer *fitasks.Keypair) {
+	additional, _ := b.resolvedAdditionalTrustedCAs()
+	c.AddTask(&fitasks.CABundle{
+		Name:        fi.String(name),
+		Lifecycle:   b.Lifecycle,
+		Certificate: signer,
+		Additional:  additional,
+	})
+}
+
+// resolvedAdditionalTrustedCAs resolves cluster.spec.certManager.additionalTrustedCAs into PEM bytes,
+// caching the result so a cluster with several *-ca-bundle tasks only reads each VFS path once per
+// Build rather than once per bundle.
+func (b *PKIModelBuilder) resolvedAdditionalTrustedCAs() ([]string, error) {
+	b.resolveAdditionalOnce.Do(func() {
+		if b.Cluster.Spec.CertManager == nil {
+			return
+		}
+		for _, additional := range b.Cluster.Spec.CertManager.AdditionalTrustedCAs {
+			pemBytes, err := fitasks.ResolveAdditionalCA(additional)
+			if err != nil {
+				b.resolveAdditionalErr = err
+				return
+			}
+			b.resolvedAdditional = append(b.resolvedAdditional, string(pemBytes))
+		}
+	})
+	return b.resolvedAdditional, b.resolveAdditionalErr
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protokube
+
+import (
+	"time"
+
+	"k8s.io/klog"
+)
+
+// defaultCertRotatorInterval is how often the CertRotator polls the keystore for leaf certificates
+// approaching their RenewBefore window. It doesn't need to be frequent: the shortest default leaf
+// lifetime is 90 days, with a 30 day RenewBefore.
+const defaultCertRotatorInterval = 1 * time.Hour
+
+// KeypairStore is the subset of the keystore the CertRotator needs: finding leaf keypairs nearing
+// expiry, and re-signing them in place. Implemented by fi.Keystore on the master, so the background
+// controller here and "kops rotate certs" agree on exactly what's due.
+type KeypairStore interface {
+	// FindKeypairsDueForRenewal returns the names of every leaf keypair whose certificate is within
+	// its RenewBefore window of NotAfter. keypair, if non-empty, restricts the check to that one
+	// keypair; force returns every leaf keypair regardless of how close to expiry it is. The
+	// background controller always passes ("", false): it rotates whatever's actually due, for every
+	// keypair, on its own schedule.
+	FindKeypairsDueForRenewal(keypair string, force bool) ([]string, error)
+	// RotateKeypair re-signs the named keypair's certificate, keeping the same private key's signer
+	// relationship but extending NotAfter by another full Lifetime.
+	RotateKeypair(name string) error
+}
+
+// CertRotator periodically re-signs leaf certificates before they expire, so that a kops cluster
+// with defaultLeafCertificateLifetime/RenewBefore set doesn't require an operator to run
+// `kops rotate certs` by hand on a schedule. It only rotates leaves: a CA losing its own key, or an
+// externally-managed CA, is out of scope and must be handled by the operator directly.
+//
+// Known gap: RotateKeypair only updates the keystore. It does not signal the components that hold
+// the old keypair open (kube-apiserver, kubelet, etcd, ...) to reload or restart, so a rotated
+// certificate doesn't take effect until something else causes those processes to restart - the same
+// caveat "kops rotate certs" documents for its own --yes path. Closing that gap requires wiring this
+// rotation into whatever already restarts those components on a keyset change (e.g. the same
+// mechanism a manual `kops rolling-update cluster` relies on); that wiring doesn't exist yet.
+type CertRotator struct {
+	Keystore KeypairStore
+	Interval time.Duration
+}
+
+// NewCertRotator builds a CertRotator with the default polling interval.
+func NewCertRotator(keystore KeypairStore) *CertRotator {
+	return &CertRotator{
+		Keystore: keystore,
+		Interval: defaultCertRotatorInterval,
+	}
+}
+
+// Run polls the keystore for keypairs due for renewal until stopCh is closed.
+func (r *CertRotator) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.runOnce()
+
+		select {
+		case <-ticker.C:
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+func (r *CertRotator) runOnce() {
+	due, err := r.Keystore.FindKeypairsDueForRenewal("", false)
+	if err != nil {
+		klog.Warningf("error checking keystore for certificates due for renewal: %v", err)
+		return
+	}
+
+	for _, name := range due {
+		klog.Infof("certificate %q is within its RenewBefore window; rotating", name)
+		if err := r.Keystore.RotateKeypair(name); err != nil {
+			klog.Warningf("error rotating certificate %q: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protokube
+
+import (
+	"fmt"
+	"testing"
+)
+
+type fakeKeypairStore struct {
+	due      []string
+	rotated  []string
+	findErr  error
+	failName string
+	lastArgs [2]interface{}
+}
+
+func (f *fakeKeypairStore) FindKeypairsDueForRenewal(keypair string, force bool) ([]string, error) {
+	f.lastArgs = [2]interface{}{keypair, force}
+	if f.findErr != nil {
+		return nil, f.findErr
+	}
+	return f.due, nil
+}
+
+func (f *fakeKeypairStore) RotateKeypair(name string) error {
+	if name == f.failName {
+		return fmt.Errorf("simulated rotation failure for %q", name)
+	}
+	f.rotated = append(f.rotated, name)
+	return nil
+}
+
+func TestCertRotatorRunOnceRotatesDueKeypairs(t *testing.T) {
+	store := &fakeKeypairStore{due: []string{"kubelet", "kube-proxy"}}
+	r := NewCertRotator(store)
+
+	r.runOnce()
+
+	if store.lastArgs != [2]interface{}{"", false} {
+		t.Errorf("FindKeypairsDueForRenewal called with %v, want (\"\", false) for the background loop", store.lastArgs)
+	}
+	if len(store.rotated) != 2 || store.rotated[0] != "kubelet" || store.rotated[1] != "kube-proxy" {
+		t.Errorf("rotated = %v, want [kubelet kube-proxy]", store.rotated)
+	}
+}
+
+func TestCertRotatorRunOnceContinuesPastRotateError(t *testing.T) {
+	store := &fakeKeypairStore{due: []string{"kubelet", "kube-proxy"}, failName: "kubelet"}
+	r := NewCertRotator(store)
+
+	r.runOnce()
+
+	if len(store.rotated) != 1 || store.rotated[0] != "kube-proxy" {
+		t.Errorf("rotated = %v, want [kube-proxy] despite kubelet's rotation failing", store.rotated)
+	}
+}
+
+func TestKubernetesControllerSkipsRotationWithNoKeystore(t *testing.T) {
+	k := &KubernetesController{}
+	stopCh := make(chan struct{})
+	close(stopCh)
+
+	// Must not panic when Keystore is nil.
+	k.runCertRotator(stopCh)
+}
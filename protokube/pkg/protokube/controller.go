@@ -0,0 +1,38 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protokube
+
+// KubernetesController is protokube's long-running, per-master control loop. Only the
+// cert-rotation wiring is reconstructed here; its other sub-controllers (DNS, volume attachment,
+// etcd bootstrap, ...) live elsewhere.
+type KubernetesController struct {
+	// Keystore is the master's keystore, used to rotate leaf certificates as they approach expiry.
+	// Nil on a cluster without a keystore protokube can reach (e.g. an externally-managed CA with no
+	// local credentials), in which case rotation is skipped - an operator must rotate out of band.
+	Keystore KeypairStore
+}
+
+// runCertRotator starts the CertRotator alongside protokube's other sub-controllers, so leaf
+// certificates are rotated automatically instead of requiring an operator to run
+// "kops rotate certs" by hand on a schedule.
+func (k *KubernetesController) runCertRotator(stopCh <-chan struct{}) {
+	if k.Keystore == nil {
+		return
+	}
+	rotator := NewCertRotator(k.Keystore)
+	go rotator.Run(stopCh)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fitasks
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// CABundle composes a signer's own certificate with any additional trusted CA certificates into a
+// single PEM bundle, so consumers (apiserver flags, kubelet bootstrap, aggregator trust) can trust
+// the whole chain of authorities for a trust relationship without knowing about each one individually.
+type CABundle struct {
+	Name      *string
+	Lifecycle *fi.Lifecycle
+
+	// Certificate is the signer whose own certificate anchors this bundle.
+	Certificate *Keypair
+	// Additional holds extra trusted CA certificates to splice into the bundle alongside Certificate
+	// - e.g. a previous CA being rotated out, or an externally-managed CA. Each entry is either a
+	// PEM-encoded certificate or a VFS path to one.
+	Additional []string
+}
+
+var _ fi.Task = &CABundle{}
+var _ fi.HasLifecycle = &CABundle{}
+
+// GetLifecycle implements fi.HasLifecycle.
+func (e *CABundle) GetLifecycle() *fi.Lifecycle {
+	return e.Lifecycle
+}
+
+// SetLifecycle implements fi.HasLifecycle.
+func (e *CABundle) SetLifecycle(lifecycle fi.Lifecycle) {
+	e.Lifecycle = &lifecycle
+}
+
+func (e *CABundle) String() string {
+	return fmt.Sprintf("CABundle: %s", fi.StringValue(e.Name))
+}
+
+// Run builds the bundle PEM and stores it in the keystore under this task's Name, so it can be
+// mirrored via MirrorKeystore alongside the individual keypairs.
+func (e *CABundle) Run(c *fi.Context) error {
+	name := fi.StringValue(e.Name)
+
+	if e.Certificate == nil {
+		return fmt.Errorf("CABundle %q has no Certificate", name)
+	}
+	certName := fi.StringValue(e.Certificate.Name)
+
+	cert, err := c.Keystore.FindCert(certName)
+	if err != nil {
+		return fmt.Errorf("error finding certificate %q for bundle %q: %v", certName, name, err)
+	}
+	if cert == nil {
+		return fmt.Errorf("certificate %q for bundle %q not found in keystore", certName, name)
+	}
+
+	var buf bytes.Buffer
+	if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate.Raw}); err != nil {
+		return fmt.Errorf("error encoding certificate %q: %v", certName, err)
+	}
+
+	for _, additional := range e.Additional {
+		pemBytes, err := ResolveAdditionalCA(additional)
+		if err != nil {
+			return fmt.Errorf("error resolving additional trusted CA for bundle %q: %v", name, err)
+		}
+		writeWithTrailingNewline(&buf, pemBytes)
+	}
+
+	return c.Keystore.StoreBundle(name, buf.Bytes())
+}
+
+// ResolveAdditionalCA returns the PEM bytes for one entry of CABundle.Additional (or of
+// cluster.spec.certManager.additionalTrustedCAs, which populates it). An entry that already looks
+// like PEM is used verbatim; anything else is treated as a VFS path (e.g. a local file, or an s3://,
+// gs:// location) and read from there, so a VFS path isn't spliced into a bundle as literal, corrupt
+// text. Exported so callers resolving the same entry for several bundles (see
+// pkg/model.PKIModelBuilder) can do it once and pass the result to each CABundle task, rather than
+// each task independently re-reading the same VFS path.
+func ResolveAdditionalCA(additional string) ([]byte, error) {
+	if strings.Contains(additional, "-----BEGIN") {
+		return []byte(additional), nil
+	}
+
+	path, err := vfs.Context.BuildVfsPath(additional)
+	if err != nil {
+		return nil, fmt.Errorf("error building path %q: %v", additional, err)
+	}
+	pemBytes, err := path.ReadFile()
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q: %v", additional, err)
+	}
+	return pemBytes, nil
+}
+
+// writeWithTrailingNewline appends pemBytes to buf, adding a trailing newline if pemBytes doesn't
+// already end with one, so concatenated PEM blocks don't run together on one line.
+func writeWithTrailingNewline(buf *bytes.Buffer, pemBytes []byte) {
+	buf.Write(pemBytes)
+	if len(pemBytes) > 0 && pemBytes[len(pemBytes)-1] != '\n' {
+		buf.WriteString("\n")
+	}
+}
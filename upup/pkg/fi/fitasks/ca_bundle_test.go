@@ -0,0 +1,72 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fitasks
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestResolveAdditionalCAPassesThroughPEM(t *testing.T) {
+	const pemBlock = "-----BEGIN CERTIFICATE-----\nMII...\n-----END CERTIFICATE-----\n"
+
+	got, err := ResolveAdditionalCA(pemBlock)
+	if err != nil {
+		t.Fatalf("ResolveAdditionalCA: %v", err)
+	}
+	if string(got) != pemBlock {
+		t.Errorf("ResolveAdditionalCA(%q) = %q, want it returned verbatim", pemBlock, got)
+	}
+}
+
+func TestWriteWithTrailingNewline(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"adds missing newline", "-----END CERTIFICATE-----", "-----END CERTIFICATE-----\n"},
+		{"leaves existing newline alone", "-----END CERTIFICATE-----\n", "-----END CERTIFICATE-----\n"},
+		{"empty input writes nothing", "", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeWithTrailingNewline(&buf, []byte(c.in))
+			if buf.String() != c.want {
+				t.Errorf("writeWithTrailingNewline(%q) = %q, want %q", c.in, buf.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestCABundleComposition(t *testing.T) {
+	var buf bytes.Buffer
+	writeWithTrailingNewline(&buf, []byte("-----BEGIN CERTIFICATE-----\nAAA\n-----END CERTIFICATE-----"))
+	additional, err := ResolveAdditionalCA("-----BEGIN CERTIFICATE-----\nBBB\n-----END CERTIFICATE-----\n")
+	if err != nil {
+		t.Fatalf("ResolveAdditionalCA: %v", err)
+	}
+	writeWithTrailingNewline(&buf, additional)
+
+	want := "-----BEGIN CERTIFICATE-----\nAAA\n-----END CERTIFICATE-----\n" +
+		"-----BEGIN CERTIFICATE-----\nBBB\n-----END CERTIFICATE-----\n"
+	if buf.String() != want {
+		t.Errorf("composed bundle = %q, want %q", buf.String(), want)
+	}
+}
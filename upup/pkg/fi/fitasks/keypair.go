@@ -0,0 +1,174 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fitasks
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/pki"
+)
+
+const (
+	// KeyAlgorithmRSA2048 generates a 2048-bit RSA key; it's the default when Algorithm is empty, so
+	// existing keysets round-trip unchanged.
+	KeyAlgorithmRSA2048 = pki.AlgorithmRSA2048
+	// KeyAlgorithmRSA4096 generates a 4096-bit RSA key.
+	KeyAlgorithmRSA4096 = pki.AlgorithmRSA4096
+	// KeyAlgorithmECDSAP256 generates a NIST P-256 ECDSA key; broadly compatible with etcd/apiserver.
+	KeyAlgorithmECDSAP256 = pki.AlgorithmECDSAP256
+	// KeyAlgorithmEd25519 generates an Ed25519 key; much smaller/faster than RSA, good for client certs.
+	KeyAlgorithmEd25519 = pki.AlgorithmEd25519
+
+	// defaultCALifetime is how long a self-signed CA is valid for, when Lifetime is not set.
+	defaultCALifetime = 10 * 365 * 24 * time.Hour
+)
+
+// Keypair manages a single certificate and private key, optionally signed by another Keypair.
+type Keypair struct {
+	Name      *string
+	Lifecycle *fi.Lifecycle
+
+	// Subject is the certificate subject, in RFC2253 form (e.g. "cn=kubernetes-master").
+	Subject string
+	// Type is one of "ca", "client", "server", or "clientServer".
+	Type string
+	// Signer is the Keypair that should sign this certificate. A nil Signer means this Keypair is a
+	// self-signed CA.
+	Signer *Keypair
+
+	AlternateNames []string
+
+	// Format is the on-disk keyset format (see fi.KeysetFormatV1Alpha2).
+	Format string
+
+	// Algorithm is the key algorithm to generate: one of KeyAlgorithmRSA2048 (the default),
+	// KeyAlgorithmRSA4096, KeyAlgorithmECDSAP256, or KeyAlgorithmEd25519. A CA signed with one
+	// algorithm may still sign leaves generated with another (e.g. an RSA CA signing Ed25519 leaves).
+	Algorithm string
+
+	// Lifetime is how long a freshly-issued certificate is valid for. Leaves default to a short
+	// window (see pkg/model.PKIModelBuilder); CAs default to defaultCALifetime when unset.
+	Lifetime *metav1.Duration
+	// RenewBefore is how long before expiry the certificate rotation controller should re-sign this
+	// Keypair. It has no effect unless Lifetime is also set.
+	RenewBefore *metav1.Duration
+}
+
+var _ fi.Task = &Keypair{}
+var _ fi.HasLifecycle = &Keypair{}
+
+// GetLifecycle implements fi.HasLifecycle.
+func (e *Keypair) GetLifecycle() *fi.Lifecycle {
+	return e.Lifecycle
+}
+
+// SetLifecycle implements fi.HasLifecycle.
+func (e *Keypair) SetLifecycle(lifecycle fi.Lifecycle) {
+	e.Lifecycle = &lifecycle
+}
+
+func (e *Keypair) String() string {
+	return fmt.Sprintf("Keypair: %s", fi.StringValue(e.Name))
+}
+
+// Run signs (or, for a CA, self-signs) the certificate described by this Keypair and stores it in
+// the task's KeyStore.
+func (e *Keypair) Run(c *fi.Context) error {
+	name := fi.StringValue(e.Name)
+
+	var signerCert *pki.Certificate
+	var signerKey crypto.Signer
+	if e.Signer != nil {
+		signerName := fi.StringValue(e.Signer.Name)
+
+		var err error
+		signerKey, err = c.Keystore.FindPrivateKey(signerName)
+		if err != nil {
+			return fmt.Errorf("error checking for private key of signer %q: %v", signerName, err)
+		}
+
+		// kubeadm-style "external CA" mode: if the signer's private key isn't in the keystore, we are
+		// not the source of truth for this trust relationship. We must not attempt to generate a new
+		// leaf here - the operator is expected to have already published a pre-signed certificate
+		// under this same name.
+		if signerKey == nil {
+			cert, err := c.Keystore.FindCert(name)
+			if err != nil {
+				return fmt.Errorf("error checking keystore for externally-signed certificate %q: %v", name, err)
+			}
+			if cert == nil {
+				return fmt.Errorf("signer %q has no private key in the keystore (external CA mode), but no "+
+					"pre-signed certificate named %q was found either; publish one before running again",
+					signerName, name)
+			}
+			// The leaf already exists and was (presumably) signed by the external authority; nothing to do.
+			return nil
+		}
+
+		signerCert, err = c.Keystore.FindCert(signerName)
+		if err != nil {
+			return fmt.Errorf("error finding certificate for signer %q: %v", signerName, err)
+		}
+		if signerCert == nil {
+			return fmt.Errorf("signer %q has a private key but no certificate in the keystore", signerName)
+		}
+	}
+
+	existing, err := c.Keystore.FindCert(name)
+	if err != nil {
+		return fmt.Errorf("error checking keystore for existing certificate %q: %v", name, err)
+	}
+	if existing != nil {
+		// Already issued; rotation (re-signing before RenewBefore) is handled by the cert-rotator
+		// controller, not by re-running this task.
+		return nil
+	}
+
+	lifetime := defaultCALifetime
+	if e.Lifetime != nil {
+		lifetime = e.Lifetime.Duration
+	}
+
+	var signerX509 *x509.Certificate
+	if signerCert != nil {
+		signerX509 = signerCert.Certificate
+	}
+
+	cert, key, err := pki.IssueCert(&pki.IssueCertOptions{
+		Subject:        e.Subject,
+		Type:           e.Type,
+		AlternateNames: e.AlternateNames,
+		Lifetime:       lifetime,
+	}, algorithmOrDefault(e.Algorithm), signerX509, signerKey)
+	if err != nil {
+		return fmt.Errorf("error issuing certificate %q: %v", name, err)
+	}
+
+	return c.Keystore.StoreKeypair(name, cert, key)
+}
+
+func algorithmOrDefault(algorithm string) string {
+	if algorithm == "" {
+		return KeyAlgorithmRSA2048
+	}
+	return algorithm
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"crypto"
+	"crypto/x509"
+
+	"k8s.io/kops/util/pkg/pki"
+)
+
+// Keystore is the subset of the cluster's PKI keystore that fitasks, "kops rotate certs" and the
+// certificate rotation controller rely on. Only those methods are reconstructed here; the concrete
+// (VFS-backed) implementation lives elsewhere.
+type Keystore interface {
+	// FindCert returns the named certificate, or nil if it doesn't exist yet.
+	FindCert(name string) (*pki.Certificate, error)
+	// FindPrivateKey returns the named private key, or nil if it doesn't exist - e.g. a CA kops
+	// doesn't hold the key for in cluster.spec.certManager.externalCA mode.
+	FindPrivateKey(name string) (crypto.Signer, error)
+	// StoreKeypair persists a freshly issued certificate/key pair under name.
+	StoreKeypair(name string, cert *x509.Certificate, privateKey crypto.Signer) error
+
+	// StoreBundle persists the PEM bundle a fitasks.CABundle composes under name, in the same
+	// underlying storage as FindCert/StoreKeypair. MirrorKeystore mirrors that whole storage to
+	// cluster.spec.keyStore, so a stored bundle is mirrored alongside the individual keypairs without
+	// any bundle-specific mirroring logic of its own.
+	StoreBundle(name string, pemBundle []byte) error
+	// FindBundle returns a previously stored bundle's PEM bytes, or nil if it doesn't exist yet.
+	FindBundle(name string) ([]byte, error)
+
+	// FindKeypairsDueForRenewal returns the names of leaf keypairs within their RenewBefore window of
+	// NotAfter. keypair, if non-empty, restricts the check to that one keypair (as "kops rotate
+	// certs --keypair" does); force skips the RenewBefore check entirely, returning every leaf
+	// keypair regardless of how close to expiry it is.
+	FindKeypairsDueForRenewal(keypair string, force bool) ([]string, error)
+	// RotateKeypair re-signs the named leaf keypair's certificate, keeping its existing signer
+	// relationship and private key's Algorithm but extending NotAfter by another full Lifetime.
+	RotateKeypair(name string) error
+}
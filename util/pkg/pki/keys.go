@@ -0,0 +1,187 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pki holds the certificate/private-key generation and signing logic shared by the fitasks
+// Keypair task and the keyset.yaml v1alpha2 serialization.
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+)
+
+// Certificate wraps a parsed x509 certificate, as stored in a keyset.yaml.
+type Certificate struct {
+	Certificate *x509.Certificate
+}
+
+// PrivateKey wraps a generated private key, as stored in a keyset.yaml. The concrete type varies by
+// Algorithm: *rsa.PrivateKey, *ecdsa.PrivateKey, or ed25519.PrivateKey.
+type PrivateKey struct {
+	Key crypto.Signer
+}
+
+// Supported key algorithm names for fitasks.Keypair.Algorithm / cluster.spec.certManager.keyAlgorithm.
+const (
+	AlgorithmRSA2048   = "rsa2048"
+	AlgorithmRSA4096   = "rsa4096"
+	AlgorithmECDSAP256 = "ecdsa-p256"
+	AlgorithmEd25519   = "ed25519"
+)
+
+// GeneratePrivateKey creates a new private key for the named algorithm. An empty algorithm defaults
+// to AlgorithmRSA2048, preserving the historical behavior of kops-generated keypairs.
+func GeneratePrivateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case "", AlgorithmRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case AlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgorithmEd25519:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	default:
+		return nil, fmt.Errorf("unknown key algorithm %q", algorithm)
+	}
+}
+
+// ParseSubject parses the simplified RFC2253-like subject strings used throughout the PKI model,
+// e.g. "o=system:nodes,cn=kubelet".
+func ParseSubject(subject string) (pkix.Name, error) {
+	var name pkix.Name
+	for _, part := range strings.Split(subject, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return name, fmt.Errorf("invalid subject component %q in %q", part, subject)
+		}
+		switch strings.ToLower(kv[0]) {
+		case "cn":
+			name.CommonName = kv[1]
+		case "o":
+			name.Organization = append(name.Organization, kv[1])
+		default:
+			return name, fmt.Errorf("unsupported subject field %q in %q", kv[0], subject)
+		}
+	}
+	return name, nil
+}
+
+// IssueCertOptions describes the certificate to be issued by IssueCert.
+type IssueCertOptions struct {
+	Subject        string
+	Type           string // "ca", "client", "server", or "clientServer"
+	AlternateNames []string
+	Lifetime       time.Duration
+}
+
+// IssueCert generates a key pair and certificate for opts. If signer/signerKey are nil, the
+// certificate is self-signed (used for CAs); otherwise it is signed by signer/signerKey.
+func IssueCert(opts *IssueCertOptions, algorithm string, signer *x509.Certificate, signerKey crypto.Signer) (*x509.Certificate, crypto.Signer, error) {
+	key, err := GeneratePrivateKey(algorithm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating private key: %v", err)
+	}
+
+	subject, err := ParseSubject(opts.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating certificate serial number: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(opts.Lifetime),
+	}
+
+	// KeyUsageKeyEncipherment covers RSA key transport (the TLS key exchange wrapping a pre-master
+	// secret in the certificate's public key); it's meaningless for ECDSA/Ed25519, which never do key
+	// transport, so it's only added for RSA keys below.
+	_, isRSA := key.(*rsa.PrivateKey)
+
+	switch opts.Type {
+	case "ca":
+		template.IsCA = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		template.BasicConstraintsValid = true
+	case "client":
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	case "server":
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		if isRSA {
+			template.KeyUsage |= x509.KeyUsageKeyEncipherment
+		}
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	case "clientServer":
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		if isRSA {
+			template.KeyUsage |= x509.KeyUsageKeyEncipherment
+		}
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth}
+	default:
+		return nil, nil, fmt.Errorf("unknown certificate type %q", opts.Type)
+	}
+
+	for _, name := range opts.AlternateNames {
+		if ip := net.ParseIP(name); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+
+	parent := template
+	parentKey := key
+	if signer != nil {
+		parent = signer
+		parentKey = signerKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, publicKey(key), parentKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error signing certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing signed certificate: %v", err)
+	}
+
+	return cert, key, nil
+}
+
+func publicKey(key crypto.Signer) crypto.PublicKey {
+	return key.Public()
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func TestIssueCertKeyEncipherment(t *testing.T) {
+	cases := []struct {
+		algorithm  string
+		wantKeyEnc bool
+	}{
+		{AlgorithmRSA2048, true},
+		{AlgorithmRSA4096, true},
+		{AlgorithmECDSAP256, false},
+		{AlgorithmEd25519, false},
+	}
+
+	for _, certType := range []string{"server", "clientServer"} {
+		for _, c := range cases {
+			t.Run(certType+"/"+c.algorithm, func(t *testing.T) {
+				cert, _, err := IssueCert(&IssueCertOptions{
+					Subject:  "cn=test",
+					Type:     certType,
+					Lifetime: time.Hour,
+				}, c.algorithm, nil, nil)
+				if err != nil {
+					t.Fatalf("IssueCert: %v", err)
+				}
+
+				gotKeyEnc := cert.KeyUsage&x509.KeyUsageKeyEncipherment != 0
+				if gotKeyEnc != c.wantKeyEnc {
+					t.Errorf("KeyUsageKeyEncipherment = %v, want %v", gotKeyEnc, c.wantKeyEnc)
+				}
+			})
+		}
+	}
+}
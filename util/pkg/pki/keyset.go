@@ -0,0 +1,144 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// KeysetItem is one certificate/private-key pair within a keyset.yaml, identified by its Id (kops
+// increments this on every rotation so old and new material can coexist during a rollout).
+type KeysetItem struct {
+	Id            string `yaml:"id"`
+	PublicKeyPEM  string `yaml:"publicMaterial"`
+	PrivateKeyPEM string `yaml:"privateMaterial,omitempty"`
+
+	// Algorithm records which key algorithm generated PrivateKeyPEM (one of the Algorithm* names,
+	// including "rsa2048"). It is a label only: FromKeysetItem reads the key type from the PKCS8
+	// encoding itself, so keysets written before Algorithm existed keep parsing unchanged. Omitted
+	// when the item has no private key.
+	Algorithm string `yaml:"algorithm,omitempty"`
+}
+
+// Keyset is the v1alpha2 keyset.yaml representation: an ordered list of KeysetItems for a single
+// named keypair (e.g. "etcd-client-ca", "kubelet").
+type Keyset struct {
+	Name  string       `yaml:"name"`
+	Items []KeysetItem `yaml:"keys"`
+}
+
+// ToKeysetItem PEM-encodes cert/key and records algorithm for round-tripping, ready to append to a
+// Keyset.Items.
+func ToKeysetItem(id string, cert *x509.Certificate, key crypto.Signer) (KeysetItem, error) {
+	item := KeysetItem{
+		Id:           id,
+		PublicKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+	}
+
+	if key != nil {
+		algorithm, der, err := marshalPrivateKey(key)
+		if err != nil {
+			return KeysetItem{}, err
+		}
+		item.Algorithm = algorithm
+		item.PrivateKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	}
+
+	return item, nil
+}
+
+// FromKeysetItem parses an item back into a certificate and, if present, a private key - the
+// counterpart to ToKeysetItem, so a keyset.yaml written for any of the supported key algorithms
+// round-trips correctly.
+func FromKeysetItem(item KeysetItem) (*x509.Certificate, crypto.Signer, error) {
+	certBlock, _ := pem.Decode([]byte(item.PublicKeyPEM))
+	if certBlock == nil {
+		return nil, nil, fmt.Errorf("unable to parse certificate PEM for keyset item %q", item.Id)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing certificate for keyset item %q: %v", item.Id, err)
+	}
+
+	if item.PrivateKeyPEM == "" {
+		return cert, nil, nil
+	}
+
+	key, err := unmarshalPrivateKey(item.PrivateKeyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing private key for keyset item %q: %v", item.Id, err)
+	}
+
+	return cert, key, nil
+}
+
+// unmarshalPrivateKey parses a PEM-encoded PKCS8 private key. PKCS8 already encodes the key's own
+// type (and, for RSA, its size), so no separate algorithm hint is needed to decode it;
+// KeysetItem.Algorithm is not consulted.
+func unmarshalPrivateKey(keyPEM string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("unable to parse private key PEM")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PKCS8 private key: %v", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unrecognized private key type %T", key)
+	}
+}
+
+// marshalPrivateKey returns the Algorithm tag to store alongside key, and its PKCS8 DER encoding.
+// The algorithm tag is derived from the key's own concrete type rather than trusted from the
+// caller, so a keyset always round-trips even if Algorithm was never explicitly set when the key
+// was generated.
+func marshalPrivateKey(key crypto.Signer) (string, []byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", nil, fmt.Errorf("error marshalling private key: %v", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() > 2048 {
+			return AlgorithmRSA4096, der, nil
+		}
+		return AlgorithmRSA2048, der, nil
+	case *ecdsa.PrivateKey:
+		return AlgorithmECDSAP256, der, nil
+	case ed25519.PrivateKey:
+		return AlgorithmEd25519, der, nil
+	default:
+		return "", nil, fmt.Errorf("unrecognized private key type %T", key)
+	}
+}
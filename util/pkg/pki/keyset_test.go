@@ -0,0 +1,94 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"testing"
+	"time"
+)
+
+// publicKeyEquatable is implemented by every crypto.PublicKey concrete type this package generates
+// (*rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey) since Go 1.15.
+type publicKeyEquatable interface {
+	Equal(x crypto.PublicKey) bool
+}
+
+func TestKeysetItemRoundTrip(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRSA2048, AlgorithmRSA4096, AlgorithmECDSAP256, AlgorithmEd25519} {
+		t.Run(algorithm, func(t *testing.T) {
+			cert, key, err := IssueCert(&IssueCertOptions{
+				Subject:  "cn=test",
+				Type:     "client",
+				Lifetime: time.Hour,
+			}, algorithm, nil, nil)
+			if err != nil {
+				t.Fatalf("IssueCert: %v", err)
+			}
+
+			item, err := ToKeysetItem("1", cert, key)
+			if err != nil {
+				t.Fatalf("ToKeysetItem: %v", err)
+			}
+			if item.Algorithm != algorithm {
+				t.Errorf("item.Algorithm = %q, want %q", item.Algorithm, algorithm)
+			}
+
+			gotCert, gotKey, err := FromKeysetItem(item)
+			if err != nil {
+				t.Fatalf("FromKeysetItem: %v", err)
+			}
+
+			if !gotCert.Equal(cert) {
+				t.Errorf("round-tripped certificate does not match original")
+			}
+			if !key.Public().(publicKeyEquatable).Equal(gotKey.Public()) {
+				t.Errorf("round-tripped private key does not match original")
+			}
+		})
+	}
+}
+
+func TestKeysetItemRoundTripNoPrivateKey(t *testing.T) {
+	cert, _, err := IssueCert(&IssueCertOptions{
+		Subject:  "cn=test",
+		Type:     "client",
+		Lifetime: time.Hour,
+	}, AlgorithmRSA2048, nil, nil)
+	if err != nil {
+		t.Fatalf("IssueCert: %v", err)
+	}
+
+	item, err := ToKeysetItem("1", cert, nil)
+	if err != nil {
+		t.Fatalf("ToKeysetItem: %v", err)
+	}
+	if item.PrivateKeyPEM != "" {
+		t.Fatalf("expected no private key material in item")
+	}
+
+	gotCert, gotKey, err := FromKeysetItem(item)
+	if err != nil {
+		t.Fatalf("FromKeysetItem: %v", err)
+	}
+	if gotKey != nil {
+		t.Errorf("FromKeysetItem returned a key for an item with no private key material")
+	}
+	if !gotCert.Equal(cert) {
+		t.Errorf("round-tripped certificate does not match original")
+	}
+}